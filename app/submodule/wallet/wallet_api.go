@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
@@ -21,10 +22,16 @@ type IWallet interface {
 	WalletAddresses() []address.Address
 	WalletSetDefault(_ context.Context, addr address.Address) error
 	WalletNewAddress(protocol address.Protocol) (address.Address, error)
+	WalletNewLedgerAddress(ctx context.Context, path string) (address.Address, error)
 	WalletImport(key *crypto.KeyInfo) (address.Address, error)
 	WalletExport(addrs []address.Address) ([]*crypto.KeyInfo, error)
 	WalletSign(ctx context.Context, k address.Address, msg []byte, _ wallet.MsgMeta) (*crypto.Signature, error)
 	WalletSignMessage(ctx context.Context, k address.Address, msg *types.UnsignedMessage) (*types.SignedMessage, error)
+	WalletSignMessages(ctx context.Context, k address.Address, msgs []*types.UnsignedMessage) ([]*types.SignedMessage, error)
+	WalletAggregateBLS(ctx context.Context, k address.Address, msgs []*types.UnsignedMessage) (*wallet.AggregateBLSSignature, error)
+	WalletSetPolicy(ctx context.Context, addr address.Address, policy wallet.Policy) error
+	WalletGetPolicy(ctx context.Context, addr address.Address) (wallet.Policy, bool, error)
+	WalletLockStatus(ctx context.Context) (bool, []address.Address, time.Time, error)
 }
 
 var ErrNoDefaultFromAddress = errors.New("unable to determine a default walletModule address")
@@ -47,8 +54,17 @@ func (walletAPI *WalletAPI) WalletBalance(ctx context.Context, addr address.Addr
 }
 
 func (walletAPI *WalletAPI) WalletHas(ctx context.Context, addr address.Address) (bool, error) {
+	if walletAPI.walletModule.Wallet.HasAddress(addr) {
+		return true, nil
+	}
+	if ledger := walletAPI.walletModule.Ledger; ledger != nil && ledger.HasAddress(addr) {
+		return true, nil
+	}
+	if remote := walletAPI.walletModule.Remote; remote != nil {
+		return remote.HasAddress(ctx, addr), nil
+	}
 
-	return walletAPI.walletModule.Wallet.HasAddress(addr), nil
+	return false, nil
 }
 
 // SetWalletDefaultAddress set the specified address as the default in the config.
@@ -73,9 +89,17 @@ func (walletAPI *WalletAPI) WalletDefaultAddress() (address.Address, error) {
 	return address.Undef, nil
 }
 
-// WalletAddresses gets addresses from the walletModule
+// WalletAddresses gets addresses from the walletModule, including any held
+// by a configured remote wallet backend or ledger device.
 func (walletAPI *WalletAPI) WalletAddresses() []address.Address {
-	return walletAPI.walletModule.Wallet.Addresses()
+	addrs := walletAPI.walletModule.Wallet.Addresses()
+	if ledger := walletAPI.walletModule.Ledger; ledger != nil {
+		addrs = append(addrs, ledger.Addresses()...)
+	}
+	if remote := walletAPI.walletModule.Remote; remote != nil {
+		addrs = append(addrs, remote.Addresses(context.TODO())...)
+	}
+	return addrs
 }
 
 // SetWalletDefaultAddress set the specified address as the default in the config.
@@ -93,12 +117,59 @@ func (walletAPI *WalletAPI) WalletSetDefault(_ context.Context, addr address.Add
 	return errors.New("addr not in the walletModule list")
 }
 
+// WalletSetPolicy installs or replaces the signing policy for addr, enforced
+// by WalletSign and WalletSignMessage going forward, and persists it under
+// policyConfigKey so NewWalletSubmodule can rehydrate it on the next start.
+func (walletAPI *WalletAPI) WalletSetPolicy(ctx context.Context, addr address.Address, policy wallet.Policy) error {
+	if walletAPI.walletModule.Policy == nil {
+		return xerrors.New("policy engine is not configured")
+	}
+
+	policies, err := loadPolicyConfig(walletAPI.walletModule.Config)
+	if err != nil {
+		return err
+	}
+	policies[addr.String()] = policy
+	if err := walletAPI.walletModule.Config.Set(policyConfigKey, policies); err != nil {
+		return err
+	}
+
+	walletAPI.walletModule.Policy.SetPolicy(addr, policy)
+	return nil
+}
+
+// WalletGetPolicy returns the signing policy configured for addr, if any.
+func (walletAPI *WalletAPI) WalletGetPolicy(ctx context.Context, addr address.Address) (wallet.Policy, bool, error) {
+	if walletAPI.walletModule.Policy == nil {
+		return wallet.Policy{}, false, xerrors.New("policy engine is not configured")
+	}
+	policy, ok := walletAPI.walletModule.Policy.GetPolicy(addr)
+	return policy, ok, nil
+}
+
 // WalletNewAddress generates a new walletModule address
 func (walletAPI *WalletAPI) WalletNewAddress(protocol address.Protocol) (address.Address, error) {
 	return walletAPI.walletModule.Wallet.NewAddress(protocol)
 }
 
+// WalletNewLedgerAddress derives a new secp256k1 address at path from the
+// configured Ledger device. Only the derivation path and public key are
+// kept, in memory (they are not written to disk); the private key never
+// leaves the device.
+func (walletAPI *WalletAPI) WalletNewLedgerAddress(ctx context.Context, path string) (address.Address, error) {
+	ledger := walletAPI.walletModule.Ledger
+	if ledger == nil {
+		return address.Undef, xerrors.New("no ledger device is configured")
+	}
+	return ledger.NewAddress(path)
+}
+
 func (walletAPI *WalletAPI) WalletDelAddress(ctx context.Context, addr address.Address) error {
+	if !walletAPI.walletModule.Wallet.HasAddress(addr) {
+		if remote := walletAPI.walletModule.Remote; remote != nil {
+			return remote.DeleteAddress(ctx, addr)
+		}
+	}
 	return walletAPI.walletModule.Wallet.WalletDelete(ctx, addr)
 }
 
@@ -111,34 +182,88 @@ func (walletAPI *WalletAPI) WalletImport(key *crypto.KeyInfo) (address.Address,
 	return addr, nil
 }
 
-// WalletExport returns the KeyInfos for the given walletModule addresses
+// WalletExport returns the KeyInfo for the given walletModule address.
+// Ledger-backed keys are never exportable: the private key never leaves
+// the device.
 func (walletAPI *WalletAPI) WalletExport(addr address.Address, password string) (*crypto.KeyInfo, error) {
+	if ledger := walletAPI.walletModule.Ledger; ledger != nil && ledger.HasAddress(addr) {
+		return ledger.Export(addr)
+	}
+	if !walletAPI.walletModule.Wallet.HasAddress(addr) {
+		if remote := walletAPI.walletModule.Remote; remote != nil {
+			return remote.Export(context.TODO(), addr)
+		}
+	}
 	return walletAPI.walletModule.Wallet.Export(addr, password)
 }
 
-func (walletAPI *WalletAPI) WalletSign(ctx context.Context, k address.Address, msg []byte, _ wallet.MsgMeta) (*crypto.Signature, error) {
-	head := walletAPI.walletModule.Chain.ChainReader.GetHead()
-	view, err := walletAPI.walletModule.Chain.ChainReader.StateView(head)
+func (walletAPI *WalletAPI) WalletSign(ctx context.Context, k address.Address, msg []byte, meta wallet.MsgMeta) (*crypto.Signature, error) {
+	keyAddr, err := walletAPI.resolveKeyAddr(ctx, k)
 	if err != nil {
-		return nil, err
+		return nil, xerrors.Errorf("failed to resolve ID address: %w", err)
 	}
 
-	keyAddr, err := view.ResolveToKeyAddr(ctx, k)
-	if err != nil {
-		return nil, xerrors.Errorf("failed to resolve ID address: %v", keyAddr)
+	return walletAPI.signResolved(ctx, keyAddr, wallet.SignRequest{Type: meta.Type}, msg)
+}
+
+// signResolved signs digest with the already-resolved keyAddr, enforcing the
+// per-key policy against keyAddr itself (the same address WalletSetPolicy is
+// keyed on) rather than whatever address form the caller passed in, then
+// dispatching to whichever backend actually holds the key. The lock scope is
+// checked only for keys held by the local wallet; ledger and remote keys are
+// not gated by it.
+// Batch callers (WalletSignMessages, WalletAggregateBLS) resolve k once and
+// call this per message so every message is still policy/lock checked
+// without repeating the chain-state resolution.
+func (walletAPI *WalletAPI) signResolved(ctx context.Context, keyAddr address.Address, req wallet.SignRequest, digest []byte) (*crypto.Signature, error) {
+	if policyEngine := walletAPI.walletModule.Policy; policyEngine != nil {
+		if err := policyEngine.Check(keyAddr, req, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	if ledger := walletAPI.walletModule.Ledger; ledger != nil && ledger.HasAddress(keyAddr) {
+		return ledger.WalletSign(ctx, keyAddr, digest, req.Msg)
+	}
+
+	if !walletAPI.walletModule.Wallet.HasAddress(keyAddr) {
+		if remote := walletAPI.walletModule.Remote; remote != nil {
+			return remote.WalletSign(ctx, keyAddr, digest, wallet.MsgMeta{
+				Type: req.Type,
+			})
+		}
+	}
+
+	// LockState only gates signing once the wallet actually has a password:
+	// an unprotected wallet has no UnLocked call to ever open it, so treating
+	// NewLockState's initial locked state as authoritative here would reject
+	// every signature for the common password-less case.
+	if walletAPI.walletModule.Wallet.HavePassword() && !walletAPI.walletModule.LockState.Allow(keyAddr, time.Now()) {
+		return nil, xerrors.Errorf("%s is locked: unlock the wallet before signing", keyAddr)
 	}
-	return walletAPI.walletModule.Wallet.WalletSign(ctx, keyAddr, msg, wallet.MsgMeta{
-		Type: wallet.MTUnknown,
+
+	return walletAPI.walletModule.Wallet.WalletSign(ctx, keyAddr, digest, wallet.MsgMeta{
+		Type: req.Type,
 	})
 }
 
 func (walletAPI *WalletAPI) WalletSignMessage(ctx context.Context, k address.Address, msg *types.UnsignedMessage) (*types.SignedMessage, error) {
+	keyAddr, err := walletAPI.resolveKeyAddr(ctx, k)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to resolve ID address: %w", err)
+	}
+
 	mb, err := msg.ToStorageBlock()
 	if err != nil {
 		return nil, xerrors.Errorf("serializing message: %w", err)
 	}
 
-	sig, err := walletAPI.WalletSign(ctx, k, mb.Cid().Bytes(), wallet.MsgMeta{})
+	sig, err := walletAPI.signResolved(ctx, keyAddr, wallet.SignRequest{
+		To:     msg.To,
+		Method: msg.Method,
+		Value:  msg.Value,
+		Msg:    msg,
+	}, mb.Cid().Bytes())
 	if err != nil {
 		return nil, xerrors.Errorf("failed to sign message: %w", err)
 	}
@@ -149,12 +274,62 @@ func (walletAPI *WalletAPI) WalletSignMessage(ctx context.Context, k address.Add
 	}, nil
 }
 
+// resolveKeyAddr resolves k to the key address that actually holds the
+// signing material, the same lookup WalletSign performs. Batch callers pin
+// the result across many messages to avoid repeating it.
+func (walletAPI *WalletAPI) resolveKeyAddr(ctx context.Context, k address.Address) (address.Address, error) {
+	head := walletAPI.walletModule.Chain.ChainReader.GetHead()
+	view, err := walletAPI.walletModule.Chain.ChainReader.StateView(head)
+	if err != nil {
+		return address.Undef, err
+	}
+	return view.ResolveToKeyAddr(ctx, k)
+}
+
+// WalletSignMessages signs every message in msgs under k in one call,
+// resolving k to its key address only once for the whole batch instead of
+// once per message, which otherwise dominates signing overhead for miner
+// windowPoSt/precommit flows.
+func (walletAPI *WalletAPI) WalletSignMessages(ctx context.Context, k address.Address, msgs []*types.UnsignedMessage) ([]*types.SignedMessage, error) {
+	return wallet.WalletSignMessages(ctx, walletAPI.signResolved, walletAPI.resolveKeyAddr, k, msgs)
+}
+
+// WalletAggregateBLS signs every message in msgs under k, which must resolve
+// to a BLS key, and aggregates the resulting signatures into one via blst.
+func (walletAPI *WalletAPI) WalletAggregateBLS(ctx context.Context, k address.Address, msgs []*types.UnsignedMessage) (*wallet.AggregateBLSSignature, error) {
+	return wallet.WalletAggregateBLS(ctx, walletAPI.signResolved, walletAPI.resolveKeyAddr, k, msgs)
+}
+
 func (walletAPI *WalletAPI) Locked(ctx context.Context, password string) error {
-	return walletAPI.walletModule.Wallet.Locked(password)
+	if err := walletAPI.walletModule.Wallet.Locked(password); err != nil {
+		return err
+	}
+	walletAPI.walletModule.LockState.Lock()
+	return nil
 }
 
-func (walletAPI *WalletAPI) UnLocked(ctx context.Context, password string) error {
-	return walletAPI.walletModule.Wallet.UnLocked(password)
+// UnLocked unlocks the wallet under scope: an optional TTL after which it
+// re-locks itself, an optional signature budget, and an optional address
+// allowlist. A zero-value scope preserves the previous behavior of
+// unlocking every address indefinitely.
+func (walletAPI *WalletAPI) UnLocked(ctx context.Context, password string, scope wallet.UnlockScope) error {
+	if err := walletAPI.walletModule.Wallet.UnLocked(password); err != nil {
+		return err
+	}
+	walletAPI.walletModule.LockState.Unlock(scope, time.Now())
+	return nil
+}
+
+// WalletLockStatus reports whether the wallet is currently locked and, if
+// not, which addresses are unlocked and when the unlock scope expires.
+func (walletAPI *WalletAPI) WalletLockStatus(ctx context.Context) (bool, []address.Address, time.Time, error) {
+	// A wallet without a password is never gated by LockState (see
+	// signResolved), so it is reported as unlocked for every address.
+	if !walletAPI.walletModule.Wallet.HavePassword() {
+		return false, walletAPI.WalletAddresses(), time.Time{}, nil
+	}
+	locked, addrs, expiresAt := walletAPI.walletModule.LockState.Status(time.Now(), walletAPI.WalletAddresses())
+	return locked, addrs, expiresAt, nil
 }
 
 func (walletAPI *WalletAPI) SetPassword(Context context.Context, password string) error {
@@ -0,0 +1,128 @@
+package wallet
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-address"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus/pkg/types"
+	"github.com/filecoin-project/venus/pkg/wallet"
+)
+
+// configStore is the subset of node configuration persistence WalletAPI
+// relies on: a dotted-path get/set, matching the semantics already used
+// throughout wallet_api.go (e.g. "walletModule.defaultAddress").
+type configStore interface {
+	Get(path string) (interface{}, error)
+	Set(path string, value interface{}) error
+}
+
+// StateView resolves an arbitrary address (e.g. an ID address) to the key
+// address that actually holds the signing material behind it.
+type StateView interface {
+	ResolveToKeyAddr(ctx context.Context, addr address.Address) (address.Address, error)
+}
+
+// ChainReader is the subset of chain state WalletAPI needs: the current
+// head, actor lookups at a tipset, and a StateView to resolve ID addresses.
+type ChainReader interface {
+	GetHead() types.TipSetKey
+	GetActorAt(ctx context.Context, tsk types.TipSetKey, addr address.Address) (*types.Actor, error)
+	StateView(tsk types.TipSetKey) (StateView, error)
+}
+
+// ChainSubmodule is the thin handle onto chain state that WalletSubmodule is
+// wired up with; wallet code only ever reaches through ChainReader.
+type ChainSubmodule struct {
+	ChainReader ChainReader
+}
+
+// WalletSubmodule collects every signing backend and piece of
+// security-sensitive state WalletAPI dispatches across: the local on-disk
+// Wallet, an optional Ledger device, an optional remote JSON-RPC signer, the
+// per-key policy engine, and the lock/unlock scope. A node builds exactly
+// one of these and hands out WalletAPI values via API().
+type WalletSubmodule struct {
+	Config configStore
+	Chain  *ChainSubmodule
+
+	Wallet *wallet.Wallet
+
+	// Ledger is nil when no hardware wallet is configured.
+	Ledger *wallet.LedgerWallet
+	// Remote is nil when no remote signer endpoints are configured.
+	Remote *wallet.RemoteWallet
+
+	Policy    *wallet.PolicyEngine
+	LockState *wallet.LockState
+}
+
+// policyConfigKey is the single config path every persisted wallet policy is
+// stored under, keyed by address string, so the full set can be enumerated
+// and reloaded at startup instead of only ever being written to.
+const policyConfigKey = "walletModule.policies"
+
+// loadPolicyConfig returns the persisted address-string -> Policy map, or an
+// empty map if nothing has been saved yet.
+func loadPolicyConfig(cfg configStore) (map[string]wallet.Policy, error) {
+	raw, err := cfg.Get(policyConfigKey)
+	if err != nil || raw == nil {
+		return make(map[string]wallet.Policy), nil
+	}
+	policies, ok := raw.(map[string]wallet.Policy)
+	if !ok {
+		return make(map[string]wallet.Policy), nil
+	}
+	return policies, nil
+}
+
+// loadPolicies rehydrates a freshly constructed PolicyEngine from whatever
+// policies WalletSetPolicy persisted in a previous run, so enforcement
+// survives a restart instead of silently resetting to unconstrained.
+func loadPolicies(cfg configStore, pe *wallet.PolicyEngine) error {
+	policies, err := loadPolicyConfig(cfg)
+	if err != nil {
+		return err
+	}
+	for addrStr, policy := range policies {
+		addr, err := address.NewFromString(addrStr)
+		if err != nil {
+			continue
+		}
+		pe.SetPolicy(addr, policy)
+	}
+	return nil
+}
+
+// NewWalletSubmodule wires together the local wallet with whichever optional
+// backends the node configured (ledger, remote is nil when not configured),
+// constructs the policy engine and rehydrates it from cfg, and starts the
+// lock state fresh. Callers build localWallet, ledger and remote themselves
+// (they each depend on config/transport concerns outside this package) and
+// pass the result in here; ledger and remote may be nil.
+func NewWalletSubmodule(cfg configStore, chain *ChainSubmodule, localWallet *wallet.Wallet, ledger *wallet.LedgerWallet, remote *wallet.RemoteWallet) (*WalletSubmodule, error) {
+	policyEngine := wallet.NewPolicyEngine()
+	if err := loadPolicies(cfg, policyEngine); err != nil {
+		return nil, xerrors.Errorf("loading persisted wallet policies: %w", err)
+	}
+
+	return &WalletSubmodule{
+		Config:    cfg,
+		Chain:     chain,
+		Wallet:    localWallet,
+		Ledger:    ledger,
+		Remote:    remote,
+		Policy:    policyEngine,
+		LockState: wallet.NewLockState(),
+	}, nil
+}
+
+// API returns the RPC-facing WalletAPI backed by this submodule. IWallet
+// already declares every method added across the wallet work (batch/
+// aggregate signing, policy, lock status), so embedding the result of API()
+// in the node's composed API struct is the only step left to expose them
+// over JSON-RPC, the same as every other submodule's API() method.
+func (walletModule *WalletSubmodule) API() *WalletAPI {
+	return &WalletAPI{walletModule: walletModule}
+}
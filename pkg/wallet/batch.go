@@ -0,0 +1,116 @@
+package wallet
+
+import (
+	"context"
+
+	blst "github.com/filecoin-project/blst/bindings/go"
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"golang.org/x/xerrors"
+
+	venuscrypto "github.com/filecoin-project/venus/pkg/crypto"
+	"github.com/filecoin-project/venus/pkg/types"
+)
+
+// keyResolver resolves an arbitrary (possibly ID) address to the key address
+// that actually holds the signing material, the same operation WalletSign
+// already performs through the chain state view. Resolution is pinned once
+// per batch so a run of windowPoSt/precommit messages for the same miner
+// doesn't pay for it on every message.
+type keyResolver func(ctx context.Context, k address.Address) (address.Address, error)
+
+// signResolver signs a single digest against an already-resolved key
+// address, running the same policy and lock checks WalletSign enforces for
+// one-off signing. Batch callers resolve k once via keyResolver and then
+// call this per message, so policy/lock state is still evaluated per
+// message without re-resolving the key address every time.
+type signResolver func(ctx context.Context, keyAddr address.Address, req SignRequest, digest []byte) (*venuscrypto.Signature, error)
+
+// WalletSignMessages signs every message in msgs under k, resolving k to its
+// key address exactly once for the whole batch and routing every signature
+// through sign, which enforces the same per-key policy and lock-scope checks
+// as WalletSign.
+func WalletSignMessages(ctx context.Context, sign signResolver, resolve keyResolver, k address.Address, msgs []*types.UnsignedMessage) ([]*types.SignedMessage, error) {
+	keyAddr, err := resolve(ctx, k)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to resolve key address: %w", err)
+	}
+
+	signed := make([]*types.SignedMessage, len(msgs))
+	for i, msg := range msgs {
+		mb, err := msg.ToStorageBlock()
+		if err != nil {
+			return nil, xerrors.Errorf("serializing message %d: %w", i, err)
+		}
+
+		req := SignRequest{To: msg.To, Method: msg.Method, Value: msg.Value, Msg: msg}
+		sig, err := sign(ctx, keyAddr, req, mb.Cid().Bytes())
+		if err != nil {
+			return nil, xerrors.Errorf("signing message %d: %w", i, err)
+		}
+
+		signed[i] = &types.SignedMessage{Message: *msg, Signature: *sig}
+	}
+
+	return signed, nil
+}
+
+// AggregateBLSSignature is the result of WalletAggregateBLS: a single
+// aggregated signature valid against the ordered list of per-message digests
+// and public keys that produced it.
+type AggregateBLSSignature struct {
+	Signature venuscrypto.Signature
+	PubKeys   [][]byte
+}
+
+// WalletAggregateBLS signs every message in msgs under k, which must resolve
+// to a BLS key, routing each signature through sign (the same policy/lock
+// enforced path as WalletSign), and folds the individual signatures into a
+// single aggregated signature via blst. Callers verify with the returned
+// per-message pubkeys rather than a single shared one, since each digest is
+// distinct.
+func WalletAggregateBLS(ctx context.Context, sign signResolver, resolve keyResolver, k address.Address, msgs []*types.UnsignedMessage) (*AggregateBLSSignature, error) {
+	keyAddr, err := resolve(ctx, k)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to resolve key address: %w", err)
+	}
+	if keyAddr.Protocol() != address.BLS {
+		return nil, xerrors.Errorf("%s is not a BLS address, cannot aggregate", keyAddr)
+	}
+
+	// A BLS address's payload *is* its serialized public key, so the
+	// pubkey is fixed for the whole batch and needs no wallet lookup.
+	pubKey := keyAddr.Payload()
+
+	sigs := make([][]byte, len(msgs))
+	pubKeys := make([][]byte, len(msgs))
+	for i, msg := range msgs {
+		mb, err := msg.ToStorageBlock()
+		if err != nil {
+			return nil, xerrors.Errorf("serializing message %d: %w", i, err)
+		}
+
+		req := SignRequest{To: msg.To, Method: msg.Method, Value: msg.Value, Msg: msg}
+		sig, err := sign(ctx, keyAddr, req, mb.Cid().Bytes())
+		if err != nil {
+			return nil, xerrors.Errorf("signing message %d: %w", i, err)
+		}
+		if sig.Type != crypto.SigTypeBLS {
+			return nil, xerrors.Errorf("key %s did not produce a BLS signature", keyAddr)
+		}
+
+		sigs[i] = sig.Data
+		pubKeys[i] = pubKey
+	}
+
+	var agg blst.P2Aggregate
+	if !agg.AggregateCompressed(sigs, true) {
+		return nil, xerrors.Errorf("blst failed to aggregate %d signatures", len(sigs))
+	}
+	aggregated := agg.ToAffine().Compress()
+
+	return &AggregateBLSSignature{
+		Signature: venuscrypto.Signature{Type: crypto.SigTypeBLS, Data: aggregated},
+		PubKeys:   pubKeys,
+	}, nil
+}
@@ -0,0 +1,96 @@
+package wallet
+
+import (
+	"context"
+	"testing"
+
+	blst "github.com/filecoin-project/blst/bindings/go"
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+
+	venuscrypto "github.com/filecoin-project/venus/pkg/crypto"
+	"github.com/filecoin-project/venus/pkg/types"
+)
+
+// testBLSDST matches the domain-separation tag filecoin-ffi signs and
+// verifies BLS signatures under; it only needs to be consistent between the
+// sign and verify calls in this test, not identical to the mainnet value.
+const testBLSDST = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_"
+
+func TestWalletAggregateBLSProducesVerifiableSignature(t *testing.T) {
+	sk := blst.KeyGen([]byte("01234567890123456789012345678901"))
+	pk := new(blst.P1Affine).From(sk)
+
+	keyAddr, err := address.NewBLSAddress(pk.Compress())
+	if err != nil {
+		t.Fatalf("deriving BLS address: %v", err)
+	}
+
+	resolve := func(ctx context.Context, k address.Address) (address.Address, error) {
+		return keyAddr, nil
+	}
+	sign := func(ctx context.Context, addr address.Address, req SignRequest, digest []byte) (*venuscrypto.Signature, error) {
+		sig := sk.Sign(digest, []byte(testBLSDST))
+		return &venuscrypto.Signature{Type: crypto.SigTypeBLS, Data: sig.Compress()}, nil
+	}
+
+	msgs := []*types.UnsignedMessage{
+		{To: keyAddr, Value: big.Zero(), Method: 0},
+		{To: keyAddr, Value: big.Zero(), Method: 1},
+		{To: keyAddr, Value: big.Zero(), Method: 2},
+	}
+
+	result, err := WalletAggregateBLS(context.Background(), sign, resolve, keyAddr, msgs)
+	if err != nil {
+		t.Fatalf("WalletAggregateBLS: %v", err)
+	}
+	if len(result.PubKeys) != len(msgs) {
+		t.Fatalf("expected %d pubkeys, got %d", len(msgs), len(result.PubKeys))
+	}
+
+	digests := make([][]byte, len(msgs))
+	for i, msg := range msgs {
+		mb, err := msg.ToStorageBlock()
+		if err != nil {
+			t.Fatalf("serializing message %d: %v", i, err)
+		}
+		digests[i] = mb.Cid().Bytes()
+	}
+
+	aggSig := new(blst.P2Affine).Uncompress(result.Signature.Data)
+	if aggSig == nil {
+		t.Fatal("failed to decompress aggregated signature")
+	}
+
+	pks := make([]*blst.P1Affine, len(result.PubKeys))
+	for i, pkBytes := range result.PubKeys {
+		pks[i] = new(blst.P1Affine).Uncompress(pkBytes)
+		if pks[i] == nil {
+			t.Fatalf("failed to decompress pubkey %d", i)
+		}
+	}
+
+	if !aggSig.AggregateVerify(true, pks, true, digests, []byte(testBLSDST)) {
+		t.Fatal("aggregated signature failed to verify against the returned per-message pubkeys")
+	}
+}
+
+func TestWalletAggregateBLSRejectsNonBLSKey(t *testing.T) {
+	secpAddr, err := address.NewSecp256k1Address([]byte("not-a-real-secp256k1-pubkey"))
+	if err != nil {
+		t.Fatalf("constructing test address: %v", err)
+	}
+
+	resolve := func(ctx context.Context, k address.Address) (address.Address, error) {
+		return secpAddr, nil
+	}
+	sign := func(ctx context.Context, addr address.Address, req SignRequest, digest []byte) (*venuscrypto.Signature, error) {
+		t.Fatal("sign should not be called for a non-BLS key")
+		return nil, nil
+	}
+
+	if _, err := WalletAggregateBLS(context.Background(), sign, resolve, secpAddr, nil); err == nil {
+		t.Fatal("expected an error aggregating under a non-BLS key")
+	}
+}
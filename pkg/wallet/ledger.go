@@ -0,0 +1,113 @@
+package wallet
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"golang.org/x/xerrors"
+
+	venuscrypto "github.com/filecoin-project/venus/pkg/crypto"
+	"github.com/filecoin-project/venus/pkg/types"
+)
+
+// LedgerKeyInfo is the only state kept for a ledger-backed address: the
+// BIP-44 derivation path and the public key the device reported for it. No
+// private material ever leaves the device, so there is nothing else to store.
+// LedgerWallet holds these in memory only; they are not written to disk.
+type LedgerKeyInfo struct {
+	Path      string
+	PublicKey []byte
+}
+
+// ledgerDevice is the minimal surface this package needs from a connected
+// Ledger Filecoin app. A concrete implementation dials the device over HID
+// (see github.com/karalabe/hid) and is injected at construction time so that
+// the signing path can be exercised without real hardware in tests.
+type ledgerDevice interface {
+	GetAddressPubKey(path string) ([]byte, error)
+	SignSECP256K1(path string, digest []byte) ([]byte, error)
+	// PromptMessage shows the decoded message on the device screen for the
+	// user to confirm before SignSECP256K1 is called.
+	PromptMessage(path string, um *types.UnsignedMessage)
+}
+
+// LedgerWallet stores derivation paths for secp256k1 addresses whose private
+// keys live on a Ledger device, and routes signing requests to it.
+type LedgerWallet struct {
+	device ledgerDevice
+	keys   map[address.Address]LedgerKeyInfo
+}
+
+// NewLedgerWallet wraps a connected ledger device. Callers obtain `device`
+// from the HID transport; it is passed in here so the wallet logic stays
+// testable without physical hardware.
+func NewLedgerWallet(device ledgerDevice) *LedgerWallet {
+	return &LedgerWallet{
+		device: device,
+		keys:   make(map[address.Address]LedgerKeyInfo),
+	}
+}
+
+// NewAddress derives a new secp256k1 address at path from the device and
+// remembers it by its public key; the private key never leaves the device.
+func (lw *LedgerWallet) NewAddress(path string) (address.Address, error) {
+	pubKey, err := lw.device.GetAddressPubKey(path)
+	if err != nil {
+		return address.Undef, xerrors.Errorf("getting pubkey from ledger: %w", err)
+	}
+
+	addr, err := address.NewSecp256k1Address(pubKey)
+	if err != nil {
+		return address.Undef, xerrors.Errorf("deriving address from ledger pubkey: %w", err)
+	}
+
+	lw.keys[addr] = LedgerKeyInfo{Path: path, PublicKey: pubKey}
+	return addr, nil
+}
+
+// HasAddress reports whether addr is backed by this device.
+func (lw *LedgerWallet) HasAddress(addr address.Address) bool {
+	_, ok := lw.keys[addr]
+	return ok
+}
+
+// Addresses lists every address derived from this device.
+func (lw *LedgerWallet) Addresses() []address.Address {
+	addrs := make([]address.Address, 0, len(lw.keys))
+	for addr := range lw.keys {
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// WalletSign asks the Ledger to sign digest with the key at addr's
+// derivation path. When um is non-nil (the caller is signing a real chain
+// message, as opposed to an arbitrary digest), it is the already-decoded
+// message driving the on-device confirmation prompt; callers that only have
+// a bare digest pass nil and the device shows just the digest.
+func (lw *LedgerWallet) WalletSign(ctx context.Context, addr address.Address, digest []byte, um *types.UnsignedMessage) (*venuscrypto.Signature, error) {
+	info, ok := lw.keys[addr]
+	if !ok {
+		return nil, xerrors.Errorf("address %s is not a ledger key", addr)
+	}
+
+	if um != nil {
+		lw.device.PromptMessage(info.Path, um)
+	}
+
+	sig, err := lw.device.SignSECP256K1(info.Path, digest)
+	if err != nil {
+		return nil, xerrors.Errorf("ledger declined to sign: %w", err)
+	}
+
+	return &venuscrypto.Signature{
+		Type: crypto.SigTypeSecp256k1,
+		Data: sig,
+	}, nil
+}
+
+// Export always fails: a Ledger never releases its private key material.
+func (lw *LedgerWallet) Export(addr address.Address) (*venuscrypto.KeyInfo, error) {
+	return nil, xerrors.Errorf("cannot export ledger-backed key %s: private key never leaves the device", addr)
+}
@@ -0,0 +1,129 @@
+package wallet
+
+import (
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+)
+
+// UnlockScope bounds what a single UnLocked call grants: a time-to-live
+// after which the wallet re-locks itself, an optional cap on the number of
+// signatures it will allow before re-locking, and an optional address
+// allowlist. A zero TTL means "no timer" and an empty Addrs means "every
+// address", matching the pre-existing unlock-everything-forever behavior.
+type UnlockScope struct {
+	TTL     time.Duration
+	MaxSigs int
+	Addrs   []address.Address
+}
+
+// LockState tracks the outcome of the most recent UnLocked call so that
+// WalletSign and WalletLockStatus can tell whether a given address is
+// currently allowed to sign. A password-protected wallet owns one of these
+// alongside its underlying Wallet.
+type LockState struct {
+	mu sync.Mutex
+
+	locked    bool
+	addrs     map[address.Address]bool // nil means "all addresses"
+	expiresAt time.Time                // zero means "no TTL"
+	sigsLeft  int                      // meaningful only when hasSigCap
+	hasSigCap bool
+}
+
+// NewLockState starts out locked, mirroring HavePassword()-gated wallets
+// that require an explicit UnLocked before any signing is allowed.
+func NewLockState() *LockState {
+	return &LockState{locked: true}
+}
+
+// Lock re-locks the wallet unconditionally, as Locked() already does.
+func (ls *LockState) Lock() {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.lockLocked()
+}
+
+// Unlock opens the wallet under scope, replacing any previous scope.
+func (ls *LockState) Unlock(scope UnlockScope, now time.Time) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	ls.locked = false
+	ls.addrs = nil
+	if len(scope.Addrs) > 0 {
+		ls.addrs = make(map[address.Address]bool, len(scope.Addrs))
+		for _, a := range scope.Addrs {
+			ls.addrs[a] = true
+		}
+	}
+
+	ls.expiresAt = time.Time{}
+	if scope.TTL > 0 {
+		ls.expiresAt = now.Add(scope.TTL)
+	}
+
+	ls.hasSigCap = scope.MaxSigs > 0
+	ls.sigsLeft = scope.MaxSigs
+}
+
+// Allow reports whether addr may sign right now, re-locking first if the
+// scope's TTL has elapsed, and otherwise consuming one signature against a
+// configured MaxSigs budget.
+func (ls *LockState) Allow(addr address.Address, now time.Time) bool {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if ls.locked {
+		return false
+	}
+	if !ls.expiresAt.IsZero() && !now.Before(ls.expiresAt) {
+		ls.lockLocked()
+		return false
+	}
+	if ls.addrs != nil && !ls.addrs[addr] {
+		return false
+	}
+	if ls.hasSigCap {
+		if ls.sigsLeft <= 0 {
+			ls.lockLocked()
+			return false
+		}
+		ls.sigsLeft--
+		if ls.sigsLeft <= 0 {
+			defer ls.lockLocked()
+		}
+	}
+	return true
+}
+
+// lockLocked re-locks under an already-held ls.mu.
+func (ls *LockState) lockLocked() {
+	ls.locked = true
+	ls.addrs = nil
+	ls.expiresAt = time.Time{}
+	ls.hasSigCap = false
+	ls.sigsLeft = 0
+}
+
+// Status reports the current lock state for WalletLockStatus. known is
+// returned as the unlocked set when the scope didn't restrict to specific
+// addresses.
+func (ls *LockState) Status(now time.Time, known []address.Address) (bool, []address.Address, time.Time) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if ls.locked || (!ls.expiresAt.IsZero() && !now.Before(ls.expiresAt)) {
+		return true, nil, time.Time{}
+	}
+
+	unlocked := known
+	if ls.addrs != nil {
+		unlocked = make([]address.Address, 0, len(ls.addrs))
+		for a := range ls.addrs {
+			unlocked = append(unlocked, a)
+		}
+	}
+	return false, unlocked, ls.expiresAt
+}
@@ -0,0 +1,81 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+)
+
+func TestLockStateStartsLocked(t *testing.T) {
+	ls := NewLockState()
+	addr, err := address.NewIDAddress(1)
+	if err != nil {
+		t.Fatalf("constructing test address: %v", err)
+	}
+
+	if ls.Allow(addr, time.Now()) {
+		t.Fatal("expected a freshly constructed LockState to start locked")
+	}
+}
+
+func TestLockStateTTLExpiry(t *testing.T) {
+	ls := NewLockState()
+	addr, err := address.NewIDAddress(2)
+	if err != nil {
+		t.Fatalf("constructing test address: %v", err)
+	}
+
+	now := time.Unix(0, 0)
+	ls.Unlock(UnlockScope{TTL: time.Minute}, now)
+
+	if !ls.Allow(addr, now.Add(30*time.Second)) {
+		t.Fatal("expected Allow to succeed before the TTL elapses")
+	}
+	if ls.Allow(addr, now.Add(2*time.Minute)) {
+		t.Fatal("expected Allow to fail once the TTL has elapsed")
+	}
+}
+
+func TestLockStateMaxSigsExpiry(t *testing.T) {
+	ls := NewLockState()
+	addr, err := address.NewIDAddress(3)
+	if err != nil {
+		t.Fatalf("constructing test address: %v", err)
+	}
+
+	now := time.Unix(0, 0)
+	ls.Unlock(UnlockScope{MaxSigs: 2}, now)
+
+	if !ls.Allow(addr, now) {
+		t.Fatal("expected the first signature to be allowed")
+	}
+	if !ls.Allow(addr, now) {
+		t.Fatal("expected the second signature to be allowed")
+	}
+	if ls.Allow(addr, now) {
+		t.Fatal("expected a third signature to be rejected once MaxSigs is exhausted")
+	}
+}
+
+func TestLockStateAddrAllowlist(t *testing.T) {
+	ls := NewLockState()
+	allowed, err := address.NewIDAddress(4)
+	if err != nil {
+		t.Fatalf("constructing test address: %v", err)
+	}
+	other, err := address.NewIDAddress(5)
+	if err != nil {
+		t.Fatalf("constructing test address: %v", err)
+	}
+
+	now := time.Unix(0, 0)
+	ls.Unlock(UnlockScope{Addrs: []address.Address{allowed}}, now)
+
+	if !ls.Allow(allowed, now) {
+		t.Fatal("expected the allowlisted address to be allowed")
+	}
+	if ls.Allow(other, now) {
+		t.Fatal("expected an address outside the allowlist to be rejected")
+	}
+}
@@ -0,0 +1,199 @@
+package wallet
+
+import (
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus/pkg/types"
+)
+
+// ErrPolicyRejected is returned by PolicyEngine.Check when a signing request
+// is denied by policy, as opposed to failing for cryptographic reasons.
+// It is a sentinel value: callers use xerrors.Is to tell the two apart.
+var ErrPolicyRejected = xerrors.New("signing request rejected by wallet policy")
+
+// Policy constrains what a single key is allowed to sign. A zero-value
+// Policy field means "unconstrained" for that dimension.
+type Policy struct {
+	// AllowedMsgTypes restricts which MsgMeta.Type values the key may sign.
+	// Empty means any type is allowed.
+	AllowedMsgTypes []MsgType
+
+	// AllowedTo restricts destination actor addresses. Empty means any
+	// destination is allowed.
+	AllowedTo []address.Address
+
+	// AllowedMethods restricts destination method numbers. Empty means any
+	// method is allowed.
+	AllowedMethods []abi.MethodNum
+
+	// MaxValue caps the value field of any single message. A zero value
+	// means unlimited.
+	MaxValue abi.TokenAmount
+
+	// MaxMsgsPerHour caps the number of messages signed within a tumbling
+	// one-hour window: the count resets to zero once a full hour has
+	// elapsed since the window started, rather than sliding continuously,
+	// so a caller can burst up to double this limit across a window
+	// boundary. Zero means unlimited.
+	MaxMsgsPerHour int
+
+	// MaxFILPerDay caps the total value signed within a tumbling
+	// one-day window, with the same reset-on-elapse (not sliding) behavior
+	// as MaxMsgsPerHour. A zero value means unlimited.
+	MaxFILPerDay abi.TokenAmount
+}
+
+// SignRequest describes the message a caller wants a policy-governed key to
+// sign, extracted from the fields policy rules can evaluate.
+type SignRequest struct {
+	Type   MsgType
+	To     address.Address
+	Method abi.MethodNum
+	Value  abi.TokenAmount
+
+	// Msg is the decoded message being signed, when one exists, so that a
+	// Ledger-backed key can show the user a readable confirmation prompt
+	// instead of a bare digest. Callers signing an arbitrary digest (not a
+	// chain message) leave this nil.
+	Msg *types.UnsignedMessage
+}
+
+// tokenBucket tracks message-count and FIL-value usage for a single key
+// across the two rate-limit windows a Policy can define.
+type tokenBucket struct {
+	hourStart time.Time
+	hourCount int
+	dayStart  time.Time
+	daySpent  abi.TokenAmount
+}
+
+// PolicyEngine evaluates Policy rules before a key is allowed to sign,
+// giving operators a way to delegate hot keys to services like miners
+// without handing over unconstrained signing power.
+type PolicyEngine struct {
+	mu       sync.Mutex
+	policies map[address.Address]Policy
+	buckets  map[address.Address]*tokenBucket
+}
+
+// NewPolicyEngine returns an empty PolicyEngine; rules are added with
+// SetPolicy.
+func NewPolicyEngine() *PolicyEngine {
+	return &PolicyEngine{
+		policies: make(map[address.Address]Policy),
+		buckets:  make(map[address.Address]*tokenBucket),
+	}
+}
+
+// SetPolicy installs or replaces the policy for addr.
+func (pe *PolicyEngine) SetPolicy(addr address.Address, policy Policy) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	pe.policies[addr] = policy
+}
+
+// GetPolicy returns the policy for addr, if any has been set.
+func (pe *PolicyEngine) GetPolicy(addr address.Address) (Policy, bool) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	policy, ok := pe.policies[addr]
+	return policy, ok
+}
+
+// Check evaluates req against addr's policy (if any) and, if it passes,
+// records the usage against addr's rate-limit buckets. The buckets tumble
+// rather than slide: hourCount/daySpent reset to zero once a full
+// hour/day has elapsed since the window opened, they do not decay
+// continuously. now is passed in by the caller so the engine stays
+// deterministic under test.
+func (pe *PolicyEngine) Check(addr address.Address, req SignRequest, now time.Time) error {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	policy, ok := pe.policies[addr]
+	if !ok {
+		return nil
+	}
+
+	// Callers signing a bare digest (no chain message) leave Value at its
+	// zero value, which carries a nil Int; treat that the same as an
+	// explicit zero rather than panicking the first time big.Add/GreaterThan
+	// touches it below.
+	if req.Value.Nil() {
+		req.Value = big.Zero()
+	}
+
+	if len(policy.AllowedMsgTypes) > 0 && !containsType(policy.AllowedMsgTypes, req.Type) {
+		return xerrors.Errorf("%w: message type %v not permitted for %s", ErrPolicyRejected, req.Type, addr)
+	}
+	if len(policy.AllowedTo) > 0 && !containsAddr(policy.AllowedTo, req.To) {
+		return xerrors.Errorf("%w: destination %s not permitted for %s", ErrPolicyRejected, req.To, addr)
+	}
+	if len(policy.AllowedMethods) > 0 && !containsMethod(policy.AllowedMethods, req.Method) {
+		return xerrors.Errorf("%w: method %d not permitted for %s", ErrPolicyRejected, req.Method, addr)
+	}
+	if !policy.MaxValue.Nil() && policy.MaxValue.GreaterThan(big.Zero()) && req.Value.GreaterThan(policy.MaxValue) {
+		return xerrors.Errorf("%w: value %s exceeds per-message cap %s for %s", ErrPolicyRejected, req.Value, policy.MaxValue, addr)
+	}
+
+	bucket := pe.buckets[addr]
+	if bucket == nil {
+		bucket = &tokenBucket{hourStart: now, dayStart: now, daySpent: big.Zero()}
+		pe.buckets[addr] = bucket
+	}
+	if now.Sub(bucket.hourStart) >= time.Hour {
+		bucket.hourStart = now
+		bucket.hourCount = 0
+	}
+	if now.Sub(bucket.dayStart) >= 24*time.Hour {
+		bucket.dayStart = now
+		bucket.daySpent = big.Zero()
+	}
+
+	if policy.MaxMsgsPerHour > 0 && bucket.hourCount+1 > policy.MaxMsgsPerHour {
+		return xerrors.Errorf("%w: %s exceeded %d msgs/hour", ErrPolicyRejected, addr, policy.MaxMsgsPerHour)
+	}
+	if !policy.MaxFILPerDay.Nil() && policy.MaxFILPerDay.GreaterThan(big.Zero()) {
+		projected := big.Add(bucket.daySpent, req.Value)
+		if projected.GreaterThan(policy.MaxFILPerDay) {
+			return xerrors.Errorf("%w: %s exceeded %s FIL/day", ErrPolicyRejected, addr, policy.MaxFILPerDay)
+		}
+	}
+
+	bucket.hourCount++
+	bucket.daySpent = big.Add(bucket.daySpent, req.Value)
+	return nil
+}
+
+func containsType(types []MsgType, t MsgType) bool {
+	for _, want := range types {
+		if want == t {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAddr(addrs []address.Address, addr address.Address) bool {
+	for _, want := range addrs {
+		if want == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func containsMethod(methods []abi.MethodNum, m abi.MethodNum) bool {
+	for _, want := range methods {
+		if want == m {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"golang.org/x/xerrors"
+)
+
+func TestPolicyEngineCheckRejectsDisallowedType(t *testing.T) {
+	pe := NewPolicyEngine()
+	addr, err := address.NewIDAddress(1)
+	if err != nil {
+		t.Fatalf("constructing test address: %v", err)
+	}
+	pe.SetPolicy(addr, Policy{AllowedMsgTypes: []MsgType{MTChainMsg}})
+
+	err = pe.Check(addr, SignRequest{Type: MTUnknown}, time.Now())
+	if !xerrors.Is(err, ErrPolicyRejected) {
+		t.Fatalf("expected ErrPolicyRejected, got %v", err)
+	}
+}
+
+func TestPolicyEngineCheckAllowsUnrestrictedAddress(t *testing.T) {
+	pe := NewPolicyEngine()
+	addr, err := address.NewIDAddress(2)
+	if err != nil {
+		t.Fatalf("constructing test address: %v", err)
+	}
+
+	if err := pe.Check(addr, SignRequest{Type: MTUnknown}, time.Now()); err != nil {
+		t.Fatalf("unexpected rejection for address with no policy set: %v", err)
+	}
+}
+
+func TestPolicyEngineCheckNilValueDoesNotPanic(t *testing.T) {
+	pe := NewPolicyEngine()
+	addr, err := address.NewIDAddress(3)
+	if err != nil {
+		t.Fatalf("constructing test address: %v", err)
+	}
+	pe.SetPolicy(addr, Policy{MaxFILPerDay: big.NewInt(100)})
+
+	// SignRequest{Type: meta.Type}, as WalletSign builds for a bare-digest
+	// signature, leaves Value at its zero value, whose embedded big.Int is
+	// nil. Check must normalize that to zero rather than panicking in
+	// big.Add/GreaterThan.
+	for i := 0; i < 2; i++ {
+		if err := pe.Check(addr, SignRequest{Type: MTUnknown}, time.Now()); err != nil {
+			t.Fatalf("unexpected rejection for nil Value: %v", err)
+		}
+	}
+}
+
+func TestPolicyEngineCheckEnforcesMaxMsgsPerHour(t *testing.T) {
+	pe := NewPolicyEngine()
+	addr, err := address.NewIDAddress(4)
+	if err != nil {
+		t.Fatalf("constructing test address: %v", err)
+	}
+	pe.SetPolicy(addr, Policy{MaxMsgsPerHour: 2})
+
+	now := time.Unix(0, 0)
+	for i := 0; i < 2; i++ {
+		if err := pe.Check(addr, SignRequest{Type: MTUnknown}, now); err != nil {
+			t.Fatalf("message %d: unexpected rejection: %v", i, err)
+		}
+	}
+	if err := pe.Check(addr, SignRequest{Type: MTUnknown}, now); !xerrors.Is(err, ErrPolicyRejected) {
+		t.Fatalf("expected ErrPolicyRejected once MaxMsgsPerHour is exceeded, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package wallet
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-jsonrpc"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus/pkg/crypto"
+)
+
+// RemoteWalletConfig describes a single external signing endpoint that a
+// RemoteWallet dials out to. Callers consult the RemoteWallet only for
+// addresses that the local wallet.Wallet does not hold.
+type RemoteWalletConfig struct {
+	URL   string
+	Token string
+}
+
+// remoteWalletAPI mirrors the subset of IWallet that a remote signer must
+// expose over JSON-RPC.
+type remoteWalletAPI struct {
+	WalletHas       func(ctx context.Context, addr address.Address) (bool, error)
+	WalletAddresses func(ctx context.Context) ([]address.Address, error)
+	WalletSign      func(ctx context.Context, k address.Address, msg []byte, meta MsgMeta) (*crypto.Signature, error)
+	WalletExport    func(ctx context.Context, addr address.Address) (*crypto.KeyInfo, error)
+	WalletDelete    func(ctx context.Context, addr address.Address) error
+}
+
+// remoteWalletEndpoint is a single dialed connection plus its closer.
+type remoteWalletEndpoint struct {
+	api    remoteWalletAPI
+	closer jsonrpc.ClientCloser
+}
+
+// RemoteWallet delegates key storage and signing to one or more external
+// JSON-RPC wallet services, so that a venus node can hand off hot keys to a
+// hardened host while continuing to manage chain sync and the mpool itself.
+type RemoteWallet struct {
+	endpoints []*remoteWalletEndpoint
+}
+
+// NewRemoteWallet dials every configured endpoint and returns a RemoteWallet
+// that fans out lookups across all of them. If any endpoint fails to dial,
+// NewRemoteWallet returns that error and no RemoteWallet.
+func NewRemoteWallet(ctx context.Context, cfgs []RemoteWalletConfig) (*RemoteWallet, error) {
+	rw := &RemoteWallet{}
+	for _, cfg := range cfgs {
+		var api remoteWalletAPI
+		headers := map[string][]string{}
+		if cfg.Token != "" {
+			headers["Authorization"] = []string{"Bearer " + cfg.Token}
+		}
+		closer, err := jsonrpc.NewMergeClient(ctx, cfg.URL, "Filecoin", []interface{}{&api}, headers)
+		if err != nil {
+			return nil, xerrors.Errorf("dialing remote wallet %s: %w", cfg.URL, err)
+		}
+		rw.endpoints = append(rw.endpoints, &remoteWalletEndpoint{api: api, closer: closer})
+	}
+	return rw, nil
+}
+
+// Close tears down every dialed endpoint.
+func (rw *RemoteWallet) Close() {
+	for _, ep := range rw.endpoints {
+		ep.closer()
+	}
+}
+
+// HasAddress reports whether any configured endpoint holds addr.
+func (rw *RemoteWallet) HasAddress(ctx context.Context, addr address.Address) bool {
+	for _, ep := range rw.endpoints {
+		if ok, err := ep.api.WalletHas(ctx, addr); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Addresses returns the union of addresses known to every endpoint.
+func (rw *RemoteWallet) Addresses(ctx context.Context) []address.Address {
+	var out []address.Address
+	for _, ep := range rw.endpoints {
+		addrs, err := ep.api.WalletAddresses(ctx)
+		if err != nil {
+			continue
+		}
+		out = append(out, addrs...)
+	}
+	return out
+}
+
+// WalletSign signs msg with addr on whichever endpoint holds the key.
+func (rw *RemoteWallet) WalletSign(ctx context.Context, addr address.Address, msg []byte, meta MsgMeta) (*crypto.Signature, error) {
+	for _, ep := range rw.endpoints {
+		ok, err := ep.api.WalletHas(ctx, addr)
+		if err != nil || !ok {
+			continue
+		}
+		return ep.api.WalletSign(ctx, addr, msg, meta)
+	}
+	return nil, xerrors.Errorf("address %s not found on any remote wallet", addr)
+}
+
+// Export returns the KeyInfo for addr from whichever endpoint holds it.
+func (rw *RemoteWallet) Export(ctx context.Context, addr address.Address) (*crypto.KeyInfo, error) {
+	for _, ep := range rw.endpoints {
+		ok, err := ep.api.WalletHas(ctx, addr)
+		if err != nil || !ok {
+			continue
+		}
+		return ep.api.WalletExport(ctx, addr)
+	}
+	return nil, xerrors.Errorf("address %s not found on any remote wallet", addr)
+}
+
+// DeleteAddress removes addr from whichever endpoint holds it.
+func (rw *RemoteWallet) DeleteAddress(ctx context.Context, addr address.Address) error {
+	for _, ep := range rw.endpoints {
+		ok, err := ep.api.WalletHas(ctx, addr)
+		if err != nil || !ok {
+			continue
+		}
+		return ep.api.WalletDelete(ctx, addr)
+	}
+	return xerrors.Errorf("address %s not found on any remote wallet", addr)
+}
@@ -0,0 +1,70 @@
+package wallet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/venus/pkg/crypto"
+)
+
+// endpoints are built by hand here rather than through NewRemoteWallet so
+// the test exercises the fan-out routing logic without dialing real
+// JSON-RPC connections.
+func TestRemoteWalletRoutesToHoldingEndpoint(t *testing.T) {
+	addrA, err := address.NewIDAddress(10)
+	if err != nil {
+		t.Fatalf("constructing test address: %v", err)
+	}
+	addrB, err := address.NewIDAddress(11)
+	if err != nil {
+		t.Fatalf("constructing test address: %v", err)
+	}
+
+	var signedBy string
+	epA := &remoteWalletEndpoint{api: remoteWalletAPI{
+		WalletHas: func(ctx context.Context, addr address.Address) (bool, error) { return addr == addrA, nil },
+		WalletSign: func(ctx context.Context, k address.Address, msg []byte, meta MsgMeta) (*crypto.Signature, error) {
+			signedBy = "A"
+			return &crypto.Signature{}, nil
+		},
+	}}
+	epB := &remoteWalletEndpoint{api: remoteWalletAPI{
+		WalletHas: func(ctx context.Context, addr address.Address) (bool, error) { return addr == addrB, nil },
+		WalletSign: func(ctx context.Context, k address.Address, msg []byte, meta MsgMeta) (*crypto.Signature, error) {
+			signedBy = "B"
+			return &crypto.Signature{}, nil
+		},
+	}}
+	rw := &RemoteWallet{endpoints: []*remoteWalletEndpoint{epA, epB}}
+
+	if !rw.HasAddress(context.Background(), addrA) {
+		t.Fatal("expected HasAddress to find addrA on endpoint A")
+	}
+	if !rw.HasAddress(context.Background(), addrB) {
+		t.Fatal("expected HasAddress to find addrB on endpoint B")
+	}
+
+	if _, err := rw.WalletSign(context.Background(), addrB, []byte("digest"), MsgMeta{}); err != nil {
+		t.Fatalf("WalletSign: %v", err)
+	}
+	if signedBy != "B" {
+		t.Fatalf("expected signing to route to the endpoint holding addrB, got %q", signedBy)
+	}
+}
+
+func TestRemoteWalletUnknownAddressErrors(t *testing.T) {
+	addr, err := address.NewIDAddress(12)
+	if err != nil {
+		t.Fatalf("constructing test address: %v", err)
+	}
+
+	rw := &RemoteWallet{}
+	if rw.HasAddress(context.Background(), addr) {
+		t.Fatal("expected HasAddress to report false with no endpoints configured")
+	}
+	if _, err := rw.WalletSign(context.Background(), addr, []byte("digest"), MsgMeta{}); err == nil {
+		t.Fatal("expected WalletSign to error when no endpoint holds the address")
+	}
+}